@@ -4,28 +4,52 @@ import (
 	"log"
 	"os"
 	"path/filepath"
-	"strings"
 )
 
+// referencesDir is the root directory that mirrors the watched source tree.
+const referencesDir = "references"
+
+// defaultIgnore is the ignore list applied when no caller-specific list is given.
+var defaultIgnore = newIgnoreList(defaultIgnorePatterns)
+
+// referenceBasePath computes the path under referencesDir that mirrors the
+// .go file at path within rootDir, with its extension stripped so a renderer
+// can append its own.
+func referenceBasePath(rootDir, path string) (string, error) {
+	relativePath, err := filepath.Rel(rootDir, path)
+	if err != nil {
+		return "", err
+	}
+
+	targetPath := filepath.Join(referencesDir, relativePath)
+	return targetPath[:len(targetPath)-len(filepath.Ext(targetPath))], nil
+}
+
 // BuildFileStructure replicates srcDir's structure inside the "references" directory
-// and generates JSON files for .go files found.
-func BuildFileStructure(srcDir string) error {
-	referencesDir := "references"
+// and renders each .go file found using the given renderers. resolver enables
+// package-mode (fully-qualified types); pass nil to format types from their
+// bare AST spelling instead.
+func BuildFileStructure(srcDir string, renderers []Renderer, resolver *typeResolver) error {
+	return buildFileStructureIn(srcDir, srcDir, defaultIgnore, renderers, resolver)
+}
 
-	// Walk through the source directory
-	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+// buildFileStructureIn replicates walkDir's structure inside referencesDir,
+// with paths resolved relative to rootDir. rootDir and walkDir are the same
+// for the initial build; a dynamically discovered subdirectory passes its
+// own path as walkDir so only that subtree is (re)built.
+func buildFileStructureIn(rootDir, walkDir string, ignore *ignoreList, renderers []Renderer, resolver *typeResolver) error {
+	return filepath.Walk(walkDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			log.Printf("Error accessing path %q: %v\n", path, err)
 			return err
 		}
 
-		// Skip the references directory and its subdirectories
-		if strings.HasPrefix(path, referencesDir) {
-			return nil
+		if info.IsDir() && ignore.Matches(path) {
+			return filepath.SkipDir
 		}
 
-		// Calculate the relative path from srcDir
-		relativePath, err := filepath.Rel(srcDir, path)
+		// Calculate the relative path from rootDir
+		relativePath, err := filepath.Rel(rootDir, path)
 		if err != nil {
 			return err
 		}
@@ -45,18 +69,10 @@ func BuildFileStructure(srcDir string) error {
 				return err
 			}
 		} else if filepath.Ext(path) == ".go" {
-			// Process only .go files
-			parser := NewFileParser()
+			// Process only .go files; ParseFile renders into references/ itself
+			parser := NewFileParser(rootDir, renderers...)
+			parser.SetTypeResolver(resolver)
 			parser.ParseFile(path)
-
-			// Convert targetPath from .go to .json
-			jsonFilePath := targetPath[:len(targetPath)-len(filepath.Ext(targetPath))] + ".json"
-
-			// Write JSON strictly to the references directory
-			if err := parser.writeJSONToFile(filepath.Join(referencesDir, jsonFilePath)); err != nil {
-				log.Printf("Error writing JSON to file: %v\n", err)
-				return err
-			}
 		}
 
 		return nil
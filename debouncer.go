@@ -0,0 +1,81 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultDebounceDelay is the default quiet-window used to coalesce bursts of
+// filesystem events for the same path before acting on them.
+const defaultDebounceDelay = 300 * time.Millisecond
+
+// debouncer coalesces repeated calls for the same key into a single call that
+// fires once no further calls for that key arrive within delay. It is used to
+// avoid reparsing a file multiple times for a single save, since editors and
+// OSes commonly emit several Write/Create events per save.
+type debouncer struct {
+	mu     sync.Mutex
+	delay  time.Duration
+	timers map[string]*time.Timer
+	fns    map[string]func()
+	wg     sync.WaitGroup
+}
+
+// newDebouncer creates a debouncer with the given quiet-window delay.
+func newDebouncer(delay time.Duration) *debouncer {
+	return &debouncer{
+		delay:  delay,
+		timers: make(map[string]*time.Timer),
+		fns:    make(map[string]func()),
+	}
+}
+
+// Schedule resets the quiet-window timer for key, replacing any pending call
+// for it, so fn runs once delay has passed since the most recent Schedule
+// call for that key.
+func (d *debouncer) Schedule(key string, fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if timer, ok := d.timers[key]; ok {
+		timer.Stop()
+	} else {
+		d.wg.Add(1)
+	}
+
+	d.fns[key] = fn
+	d.timers[key] = time.AfterFunc(d.delay, func() { d.fire(key) })
+}
+
+// fire invokes and clears the pending call for key, if one is still pending.
+func (d *debouncer) fire(key string) {
+	d.mu.Lock()
+	fn, ok := d.fns[key]
+	if ok {
+		delete(d.fns, key)
+		delete(d.timers, key)
+	}
+	d.mu.Unlock()
+
+	if ok {
+		fn()
+		d.wg.Done()
+	}
+}
+
+// Flush runs any pending calls immediately and waits for in-flight calls to
+// finish, so a shutdown never drops a parse that was still debouncing.
+func (d *debouncer) Flush() {
+	d.mu.Lock()
+	keys := make([]string, 0, len(d.timers))
+	for key, timer := range d.timers {
+		timer.Stop()
+		keys = append(keys, key)
+	}
+	d.mu.Unlock()
+
+	for _, key := range keys {
+		d.fire(key)
+	}
+	d.wg.Wait()
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebouncerCoalescesBurstsPerKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		schedules  int
+		keys       []string
+		wantCalls  int32
+		wantPerKey map[string]int32
+	}{
+		{
+			name:       "single key, repeated schedules fire once",
+			schedules:  5,
+			keys:       []string{"a", "a", "a", "a", "a"},
+			wantCalls:  1,
+			wantPerKey: map[string]int32{"a": 1},
+		},
+		{
+			name:       "distinct keys fire independently",
+			schedules:  3,
+			keys:       []string{"a", "b", "c"},
+			wantCalls:  3,
+			wantPerKey: map[string]int32{"a": 1, "b": 1, "c": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDebouncer(10 * time.Millisecond)
+
+			var calls int32
+			perKey := make(map[string]*int32, len(tt.wantPerKey))
+			for key := range tt.wantPerKey {
+				var n int32
+				perKey[key] = &n
+			}
+
+			for _, key := range tt.keys {
+				key := key
+				counter := perKey[key]
+				d.Schedule(key, func() {
+					atomic.AddInt32(&calls, 1)
+					atomic.AddInt32(counter, 1)
+				})
+			}
+
+			d.Flush()
+
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("total calls = %d, want %d", got, tt.wantCalls)
+			}
+			for key, want := range tt.wantPerKey {
+				if got := atomic.LoadInt32(perKey[key]); got != want {
+					t.Errorf("calls for key %q = %d, want %d", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDebouncerScheduleResetsPendingTimer(t *testing.T) {
+	d := newDebouncer(50 * time.Millisecond)
+
+	var calls int32
+	d.Schedule("a", func() { atomic.AddInt32(&calls, 1) })
+	time.Sleep(20 * time.Millisecond)
+	d.Schedule("a", func() { atomic.AddInt32(&calls, 1) }) // resets the window before it fires
+
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Fatalf("call fired before the quiet-window elapsed: calls = %d", got)
+	}
+
+	d.Flush()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("calls after Flush = %d, want 1", got)
+	}
+}
+
+func TestDebouncerFlushRunsPendingCallsImmediately(t *testing.T) {
+	d := newDebouncer(time.Hour) // would never fire naturally within the test
+
+	fired := make(chan struct{}, 1)
+	d.Schedule("a", func() { fired <- struct{}{} })
+
+	d.Flush()
+
+	select {
+	case <-fired:
+	default:
+		t.Fatal("Flush did not run the pending call")
+	}
+}
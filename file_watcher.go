@@ -18,8 +18,16 @@ type FileWatcher struct {
 	watcher    *fsnotify.Watcher
 	parser     *FileParser
 	logFile    *os.File
-	recentLogs []string   // Store recent log entries
-	mu         sync.Mutex // Mutex for thread-safe access to recentLogs
+	recentLogs []string    // Store recent log entries
+	mu         sync.Mutex  // Mutex for thread-safe access to recentLogs
+	rootDir    string      // Directory passed to Watch, used to resolve reference paths
+	debounce   *debouncer  // Coalesces bursts of events per path before reparsing
+	ignore     *ignoreList // Directories skipped at initial walk and dynamic add time
+
+	dirMu sync.Mutex          // Mutex for thread-safe access to dirs
+	dirs  map[string]struct{} // Directories currently registered with the watcher
+
+	notifier *reloadNotifier // Optional live-reload fan-out; nil unless the HTTP server is enabled
 }
 
 // NewFileWatcher initializes a new FileWatcher
@@ -48,11 +56,35 @@ func NewFileWatcher(parser *FileParser) (*FileWatcher, error) {
 		parser:     parser,
 		logFile:    logFile,
 		recentLogs: []string{}, // Initialize the array for recent logs
+		debounce:   newDebouncer(defaultDebounceDelay),
+		ignore:     newIgnoreList(defaultIgnorePatterns),
+		dirs:       make(map[string]struct{}),
 	}, nil
 }
 
+// SetDebounceDelay overrides the default quiet-window used to coalesce
+// bursts of events per file before reparsing. It must be called before Watch.
+func (fw *FileWatcher) SetDebounceDelay(delay time.Duration) {
+	fw.debounce = newDebouncer(delay)
+}
+
+// SetIgnorePatterns overrides the default directory name globs skipped at
+// initial walk and dynamic add time. It must be called before Watch.
+func (fw *FileWatcher) SetIgnorePatterns(patterns []string) {
+	fw.ignore = newIgnoreList(patterns)
+}
+
+// SetReloadNotifier enables live-reload: notifier is broadcast to every time
+// a watched file is reparsed or removed, so the HTTP server can push a
+// websocket message to connected browsers. It must be called before Watch.
+func (fw *FileWatcher) SetReloadNotifier(notifier *reloadNotifier) {
+	fw.notifier = notifier
+}
+
 // Watch starts watching the specified directory and its subdirectories
 func (fw *FileWatcher) Watch(dir string) {
+	fw.rootDir = dir
+
 	// Add the directory to be watched
 	if err := fw.watcher.Add(dir); err != nil {
 		log.Fatalf("Failed to watch directory: %s", err)
@@ -72,17 +104,31 @@ func (fw *FileWatcher) Watch(dir string) {
 				if !ok {
 					return
 				}
-				// Ignore remove events
-				if event.Op&fsnotify.Remove == fsnotify.Remove {
-					continue
-				}
 
 				// Log the event
 				fw.logEvent(event)
 
-				// Parse the changed file
-				if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create {
-					fw.parser.ParseFile(event.Name)
+				switch {
+				case event.Op&fsnotify.Create == fsnotify.Create:
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						fw.addDirectory(event.Name)
+						break
+					}
+					fallthrough
+				case event.Op&fsnotify.Write == fsnotify.Write:
+					// Debounce bursts of events for the same path before reparsing,
+					// since editors and OSes commonly fire several per save.
+					path := event.Name
+					fw.debounce.Schedule(path, func() {
+						// Package-mode's resolver is a point-in-time snapshot; refresh
+						// it before reparsing so this edit's types are what gets
+						// resolved, not whatever was true at startup or the last save.
+						fw.parser.RefreshResolver()
+						fw.parser.ParseFile(path)
+						fw.notifyReload()
+					})
+				case event.Op&fsnotify.Remove == fsnotify.Remove || event.Op&fsnotify.Rename == fsnotify.Rename:
+					fw.handleRemoval(event.Name)
 				}
 			case err, ok := <-fw.watcher.Errors:
 				if !ok {
@@ -98,17 +144,94 @@ func (fw *FileWatcher) Watch(dir string) {
 	}()
 }
 
-// watchSubdirectories recursively watches all subdirectories
+// handleRemoval tears down whatever was at path: for a tracked directory, its
+// mirrored subtree under references/ is removed; for a file, its parsed data
+// and mirrored output files are removed. This keeps references/ clean when a
+// .go file or directory is renamed or deleted.
+func (fw *FileWatcher) handleRemoval(path string) {
+	fw.dirMu.Lock()
+	_, wasDir := fw.dirs[path]
+	delete(fw.dirs, path)
+	fw.dirMu.Unlock()
+
+	if wasDir {
+		fw.removeDirectory(path)
+		return
+	}
+
+	fw.parser.RemoveFile(path)
+
+	basePath, err := referenceBasePath(fw.rootDir, path)
+	if err != nil {
+		log.Printf("Error resolving reference path for %q: %v", path, err)
+		return
+	}
+
+	for _, renderer := range fw.parser.Renderers() {
+		outPath := basePath + renderer.Ext()
+		if err := os.Remove(outPath); err != nil && !os.IsNotExist(err) {
+			log.Printf("Error removing stale reference %q: %v", outPath, err)
+		}
+	}
+
+	fw.notifyReload()
+}
+
+// notifyReload broadcasts a live-reload signal, if a reloadNotifier was
+// configured via SetReloadNotifier.
+func (fw *FileWatcher) notifyReload() {
+	if fw.notifier != nil {
+		fw.notifier.Broadcast()
+	}
+}
+
+// addDirectory watches a newly created directory and, recursively, any
+// subdirectories beneath it, then builds references/ for the new subtree.
+// Directories matching the configured ignore patterns are skipped.
+func (fw *FileWatcher) addDirectory(dir string) {
+	if fw.ignore.Matches(dir) {
+		return
+	}
+
+	fw.watchSubdirectories(dir)
+
+	if err := buildFileStructureIn(fw.rootDir, dir, fw.ignore, fw.parser.Renderers(), fw.parser.Resolver()); err != nil {
+		log.Printf("Error building references for %q: %v", dir, err)
+	}
+}
+
+// removeDirectory tears down the references/ mirror for a watched directory
+// that was removed or renamed away.
+func (fw *FileWatcher) removeDirectory(dir string) {
+	relativePath, err := filepath.Rel(fw.rootDir, dir)
+	if err != nil {
+		log.Printf("Error resolving reference path for %q: %v", dir, err)
+		return
+	}
+
+	mirrorPath := filepath.Join(referencesDir, relativePath)
+	if err := os.RemoveAll(mirrorPath); err != nil && !os.IsNotExist(err) {
+		log.Printf("Error removing stale reference directory %q: %v", mirrorPath, err)
+	}
+}
+
+// watchSubdirectories recursively watches dir and all of its subdirectories,
+// skipping any that match the configured ignore patterns.
 func (fw *FileWatcher) watchSubdirectories(dir string) {
-	// Use Walk to add all subdirectories to the watcher
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 		if info.IsDir() {
+			if fw.ignore.Matches(path) {
+				return filepath.SkipDir
+			}
 			if err := fw.watcher.Add(path); err != nil {
 				return err
 			}
+			fw.dirMu.Lock()
+			fw.dirs[path] = struct{}{}
+			fw.dirMu.Unlock()
 		}
 		return nil
 	})
@@ -151,8 +274,9 @@ func (fw *FileWatcher) Wait() {
 	fw.Close()   // Close the file watcher and log file
 }
 
-// Close closes the file watcher and log file
+// Close drains any pending debounced parses, then closes the file watcher and log file
 func (fw *FileWatcher) Close() {
+	fw.debounce.Flush()
 	fw.watcher.Close()
 	fw.logFile.Close()
 }
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HTMLRenderer renders FileData as a static HTML documentation page.
+type HTMLRenderer struct{}
+
+// Ext returns the file extension for HTML output.
+func (HTMLRenderer) Ext() string { return ".html" }
+
+// Render writes an HTML page for data to w: the package, its imports, and a
+// section per type, constant, variable, function, and method.
+func (HTMLRenderer) Render(data FileData, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>%s</title></head><body>\n", html.EscapeString(data.Package))
+	fmt.Fprintf(bw, "<h1>Package %s</h1>\n", html.EscapeString(data.Package))
+
+	if len(data.Imports) > 0 {
+		fmt.Fprintln(bw, "<h2>Imports</h2>\n<ul>")
+		for _, imp := range data.Imports {
+			fmt.Fprintf(bw, "<li><code>%s</code></li>\n", html.EscapeString(imp))
+		}
+		fmt.Fprintln(bw, "</ul>")
+	}
+
+	if len(data.Types) > 0 {
+		fmt.Fprintln(bw, "<h2>Types</h2>")
+		for _, t := range data.Types {
+			renderHTMLType(bw, t)
+		}
+	}
+
+	if len(data.Constants) > 0 {
+		fmt.Fprintln(bw, "<h2>Constants</h2>")
+		for _, v := range data.Constants {
+			renderHTMLValue(bw, v)
+		}
+	}
+
+	if len(data.Variables) > 0 {
+		fmt.Fprintln(bw, "<h2>Variables</h2>")
+		for _, v := range data.Variables {
+			renderHTMLValue(bw, v)
+		}
+	}
+
+	functions, methods := splitFunctionsAndMethods(data.Functions)
+
+	if len(functions) > 0 {
+		fmt.Fprintln(bw, "<h2>Functions</h2>")
+		for _, fn := range functions {
+			renderHTMLFunction(bw, fn)
+		}
+	}
+
+	if len(methods) > 0 {
+		fmt.Fprintln(bw, "<h2>Methods</h2>")
+		for _, fn := range methods {
+			renderHTMLFunction(bw, fn)
+		}
+	}
+
+	bw.WriteString(liveReloadScript)
+	fmt.Fprintln(bw, "</body></html>")
+
+	return bw.Flush()
+}
+
+// liveReloadScript connects to the optional live-reload HTTP server's /ws
+// endpoint and reloads the page on any message. It is embedded in every
+// rendered page so the feature needs no external assets; the connection
+// simply fails silently when the server isn't running.
+const liveReloadScript = `<script>
+(function() {
+	var proto = location.protocol === "https:" ? "wss://" : "ws://";
+	var ws = new WebSocket(proto + location.host + "/ws");
+	ws.onmessage = function() { location.reload(); };
+})();
+</script>
+`
+
+// renderHTMLType writes one type's kind-specific detail (struct fields or
+// interface methods) and doc comment as an HTML section.
+func renderHTMLType(w io.Writer, t TypeDetail) {
+	fmt.Fprintf(w, "<h3><code>%s</code></h3>\n", html.EscapeString(t.Name))
+
+	switch {
+	case len(t.Fields) > 0:
+		fmt.Fprintln(w, "<ul>")
+		for _, f := range t.Fields {
+			fmt.Fprintf(w, "<li><code>%s %s</code> %s</li>\n", html.EscapeString(f.Name), html.EscapeString(f.Type), html.EscapeString(f.Tag))
+		}
+		fmt.Fprintln(w, "</ul>")
+	case len(t.Methods) > 0:
+		fmt.Fprintf(w, "<pre><code>type %s interface {\n", html.EscapeString(t.Name))
+		for _, m := range t.Methods {
+			fmt.Fprintf(w, "\t%s(%s) %s\n", html.EscapeString(m.Name), html.EscapeString(strings.Join(m.ParamTypes, ", ")), html.EscapeString(strings.Join(m.ReturnTypes, ", ")))
+		}
+		fmt.Fprintln(w, "}</code></pre>")
+	case t.Underlying != "":
+		op := ""
+		if t.IsAlias {
+			op = "= "
+		}
+		fmt.Fprintf(w, "<pre><code>type %s %s%s</code></pre>\n", html.EscapeString(t.Name), op, html.EscapeString(t.Underlying))
+	}
+
+	if t.Docs != "" {
+		fmt.Fprintf(w, "<p>%s</p>\n", html.EscapeString(t.Docs))
+	}
+}
+
+// renderHTMLValue writes one const or var's type, value, and doc comment as
+// an HTML section.
+func renderHTMLValue(w io.Writer, v ValueDetail) {
+	fmt.Fprintf(w, "<h3><code>%s</code></h3>\n", html.EscapeString(v.Name))
+
+	if v.Type != "" || v.Value != "" {
+		fmt.Fprintf(w, "<pre><code>%s %s = %s</code></pre>\n", html.EscapeString(v.Name), html.EscapeString(v.Type), html.EscapeString(v.Value))
+	}
+
+	if v.Docs != "" {
+		fmt.Fprintf(w, "<p>%s</p>\n", html.EscapeString(v.Docs))
+	}
+}
+
+// renderHTMLFunction writes one function or method's signature, params,
+// return types, and doc comment as an HTML section.
+func renderHTMLFunction(w io.Writer, fn FunctionDetail) {
+	heading := fn.Name
+	if fn.Receiver != "" {
+		heading = fmt.Sprintf("(%s) %s", fn.Receiver, fn.Name)
+	}
+	fmt.Fprintf(w, "<h3><code>%s</code></h3>\n", html.EscapeString(heading))
+	fmt.Fprintf(w, "<pre><code>func %s(%s) %s</code></pre>\n",
+		html.EscapeString(fn.Name),
+		html.EscapeString(strings.Join(fn.ParamTypes, ", ")),
+		html.EscapeString(strings.Join(fn.ReturnTypes, ", ")))
+
+	if len(fn.Params) > 0 {
+		fmt.Fprintf(w, "<p><strong>Params:</strong> %s</p>\n", html.EscapeString(strings.Join(fn.Params, ", ")))
+	}
+
+	if fn.Docs != "" {
+		fmt.Fprintf(w, "<p>%s</p>\n", html.EscapeString(fn.Docs))
+	}
+}
+
+// writeHTMLIndex (re)writes references/index.html, a page linking to every
+// rendered .html doc page in the references tree, keeping the live docs site
+// navigable as files change.
+func writeHTMLIndex() error {
+	var buf bytes.Buffer
+	buf.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Documentation</title></head><body>\n")
+	buf.WriteString("<h1>Documentation</h1>\n<ul>\n")
+
+	err := filepath.Walk(referencesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".html" || filepath.Base(path) == "index.html" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(referencesDir, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "<li><a href=\"%s\">%s</a></li>\n", rel, html.EscapeString(rel))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString("</ul>\n")
+	buf.WriteString(liveReloadScript)
+	buf.WriteString("</body></html>\n")
+
+	return os.WriteFile(filepath.Join(referencesDir, "index.html"), buf.Bytes(), 0644)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHTMLRendererRender(t *testing.T) {
+	tests := []struct {
+		name string
+		data FileData
+		want []string // substrings that must appear, in order
+	}{
+		{
+			name: "package heading and escaping",
+			data: FileData{Package: "a<b>"},
+			want: []string{"<title>a&lt;b&gt;</title>", "<h1>Package a&lt;b&gt;</h1>"},
+		},
+		{
+			name: "struct type with fields",
+			data: FileData{
+				Package: "widgets",
+				Types: []TypeDetail{
+					{
+						Name:   "Widget",
+						Docs:   "Widget is a thing.",
+						Fields: []FieldDetail{{Name: "ID", Type: "string"}},
+					},
+				},
+			},
+			want: []string{"<h3><code>Widget</code></h3>", "<li><code>ID string</code>", "<p>Widget is a thing.</p>"},
+		},
+		{
+			name: "function and method sections are split",
+			data: FileData{
+				Package: "widgets",
+				Functions: []FunctionDetail{
+					{Name: "New", ReturnTypes: []string{"*Widget"}},
+					{Name: "String", Receiver: "*Widget", ReturnTypes: []string{"string"}},
+				},
+			},
+			want: []string{"<h2>Functions</h2>", "<code>New</code>", "<h2>Methods</h2>", "<code>(*Widget) String</code>"},
+		},
+		{
+			name: "always embeds the live-reload script",
+			data: FileData{Package: "widgets"},
+			want: []string{"new WebSocket(proto + location.host + \"/ws\")"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (HTMLRenderer{}).Render(tt.data, &buf); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			out := buf.String()
+
+			pos := -1
+			for _, want := range tt.want {
+				idx := strings.Index(out, want)
+				if idx == -1 {
+					t.Fatalf("output missing %q; got:\n%s", want, out)
+				}
+				if idx < pos {
+					t.Errorf("expected %q to appear after previous match; got:\n%s", want, out)
+				}
+				pos = idx
+			}
+		})
+	}
+}
+
+func TestHTMLRendererExt(t *testing.T) {
+	if got := (HTMLRenderer{}).Ext(); got != ".html" {
+		t.Errorf("Ext() = %q, want %q", got, ".html")
+	}
+}
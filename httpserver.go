@@ -0,0 +1,79 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// upgrader upgrades GET /ws requests to websocket connections for the
+// live-reload feature. Origin checking is skipped, since this server is
+// meant for local development use only.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// serveReferences starts an HTTP server on addr that serves the references/
+// tree and, over /ws, pushes a message to connected browsers each time
+// notifier is broadcast to. It runs on its own goroutine (see main), so a
+// failure here must not take down the watcher: it is logged and
+// serveReferences simply returns, leaving documentation generation running.
+func serveReferences(addr string, notifier *reloadNotifier) {
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir(referencesDir)))
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		handleWebsocket(w, r, notifier)
+	})
+
+	log.Printf("Serving %s on http://%s", referencesDir, addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("HTTP server error: %v", err)
+	}
+}
+
+// handleWebsocket upgrades the request to a websocket connection, then
+// writes a reload message to it each time notifier fires, until the client
+// disconnects.
+func handleWebsocket(w http.ResponseWriter, r *http.Request, notifier *reloadNotifier) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Error upgrading websocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ch, unsubscribe := notifier.Subscribe()
+	defer unsubscribe()
+
+	// The browser never sends us anything once connected, but without
+	// reading from conn there is no way to notice it went away between
+	// reloads: an idle disconnect would otherwise leak this goroutine, the
+	// notifier subscription, and conn until the next Broadcast happened to
+	// fail a write.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte("reload")); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
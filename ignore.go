@@ -0,0 +1,40 @@
+package main
+
+import "path/filepath"
+
+// defaultIgnorePatterns are the directory name globs skipped during the
+// initial walk and when watching for new subdirectories.
+var defaultIgnorePatterns = []string{
+	".git",
+	"vendor",
+	"node_modules",
+	".*",
+	referencesDir,
+}
+
+// ignoreList matches a directory's base name against a set of glob patterns.
+type ignoreList struct {
+	patterns []string
+}
+
+// newIgnoreList creates an ignoreList from the given glob patterns, as
+// understood by filepath.Match.
+func newIgnoreList(patterns []string) *ignoreList {
+	return &ignoreList{patterns: patterns}
+}
+
+// Matches reports whether the base name of path matches any configured pattern.
+func (il *ignoreList) Matches(path string) bool {
+	name := filepath.Base(path)
+	if name == "." || name == ".." {
+		// filepath.Base of a walk root (e.g. ".") must never be treated as hidden.
+		return false
+	}
+
+	for _, pattern := range il.patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONRenderer renders FileData as indented JSON.
+type JSONRenderer struct{}
+
+// Ext returns the file extension for JSON output.
+func (JSONRenderer) Ext() string { return ".json" }
+
+// Render writes data to w as indented JSON.
+func (JSONRenderer) Render(data FileData, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
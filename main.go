@@ -1,23 +1,44 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
-	"os"
+	"strings"
 )
 
 func main() {
+	formatFlag := flag.String("format", "json", "comma-separated output formats to generate (json,md,html)")
+	resolveTypesFlag := flag.Bool("resolve-types", false, "resolve types via go/types for fully-qualified output (package-mode)")
+	httpFlag := flag.String("http", "", "serve references/ with live-reload at this address (e.g. :8080); disabled if empty")
+	flag.Parse()
+
 	// Check if a directory path is provided as an argument
-	if len(os.Args) < 2 {
+	if flag.NArg() < 1 {
 		log.Fatal("Please provide a directory path to watch.")
 	}
 
-	dir := os.Args[1]
+	dir := flag.Arg(0)
+
+	renderers, err := parseRenderers(*formatFlag)
+	if err != nil {
+		log.Fatalf("Invalid -format value: %v", err)
+	}
+
+	var resolver *typeResolver
+	if *resolveTypesFlag {
+		resolver, err = loadTypeResolver(dir)
+		if err != nil {
+			log.Fatalf("Error loading types for -resolve-types: %v", err)
+		}
+	}
 
 	// Create a new FileParser
-	parser := NewFileParser()
+	parser := NewFileParser(dir, renderers...)
+	parser.SetTypeResolver(resolver)
 
 	// Build the initial directory structure for references
-	if err := BuildFileStructure(dir); err != nil {
+	if err := BuildFileStructure(dir, renderers, resolver); err != nil {
 		log.Fatalf("Error creating directory structure: %v", err)
 	}
 
@@ -27,6 +48,12 @@ func main() {
 		log.Fatalf("Error initializing file watcher: %v", err)
 	}
 
+	if *httpFlag != "" {
+		notifier := newReloadNotifier()
+		fileWatcher.SetReloadNotifier(notifier)
+		go serveReferences(*httpFlag, notifier)
+	}
+
 	// Start watching the specified directory
 	fileWatcher.Watch(dir)
 
@@ -35,3 +62,24 @@ func main() {
 
 	log.Println("File watcher stopped.")
 }
+
+// parseRenderers converts a comma-separated list of format names ("json",
+// "md", "html") into the Renderers that produce them.
+func parseRenderers(formats string) ([]Renderer, error) {
+	var renderers []Renderer
+
+	for _, name := range strings.Split(formats, ",") {
+		switch strings.TrimSpace(name) {
+		case "json":
+			renderers = append(renderers, JSONRenderer{})
+		case "md":
+			renderers = append(renderers, MarkdownRenderer{})
+		case "html":
+			renderers = append(renderers, HTMLRenderer{})
+		default:
+			return nil, fmt.Errorf("unknown format %q", name)
+		}
+	}
+
+	return renderers, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownRenderer renders FileData as a Markdown documentation page.
+type MarkdownRenderer struct{}
+
+// Ext returns the file extension for Markdown output.
+func (MarkdownRenderer) Ext() string { return ".md" }
+
+// Render writes a Markdown page for data to w: the package, its imports, and
+// a section per type, constant, variable, function, and method.
+func (MarkdownRenderer) Render(data FileData, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# Package `%s`\n\n", data.Package)
+
+	if len(data.Imports) > 0 {
+		fmt.Fprintln(bw, "## Imports")
+		for _, imp := range data.Imports {
+			fmt.Fprintf(bw, "- `%s`\n", imp)
+		}
+		fmt.Fprintln(bw)
+	}
+
+	if len(data.Types) > 0 {
+		fmt.Fprintln(bw, "## Types")
+		for _, t := range data.Types {
+			renderMarkdownType(bw, t)
+		}
+	}
+
+	if len(data.Constants) > 0 {
+		fmt.Fprintln(bw, "## Constants")
+		for _, v := range data.Constants {
+			renderMarkdownValue(bw, v)
+		}
+	}
+
+	if len(data.Variables) > 0 {
+		fmt.Fprintln(bw, "## Variables")
+		for _, v := range data.Variables {
+			renderMarkdownValue(bw, v)
+		}
+	}
+
+	functions, methods := splitFunctionsAndMethods(data.Functions)
+
+	if len(functions) > 0 {
+		fmt.Fprintln(bw, "## Functions")
+		for _, fn := range functions {
+			renderMarkdownFunction(bw, fn)
+		}
+	}
+
+	if len(methods) > 0 {
+		fmt.Fprintln(bw, "## Methods")
+		for _, fn := range methods {
+			renderMarkdownFunction(bw, fn)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// renderMarkdownType writes one type's kind-specific detail (struct fields
+// or interface methods) and doc comment as a Markdown subsection.
+func renderMarkdownType(w io.Writer, t TypeDetail) {
+	fmt.Fprintf(w, "\n### `%s`\n\n", t.Name)
+
+	switch {
+	case len(t.Fields) > 0:
+		fmt.Fprintln(w, "| Field | Type | Tag |")
+		fmt.Fprintln(w, "|---|---|---|")
+		for _, f := range t.Fields {
+			fmt.Fprintf(w, "| %s | `%s` | `%s` |\n", f.Name, f.Type, f.Tag)
+		}
+		fmt.Fprintln(w)
+	case len(t.Methods) > 0:
+		fmt.Fprintln(w, "```go\ntype "+t.Name+" interface {")
+		for _, m := range t.Methods {
+			fmt.Fprintf(w, "\t%s(%s) %s\n", m.Name, strings.Join(m.ParamTypes, ", "), strings.Join(m.ReturnTypes, ", "))
+		}
+		fmt.Fprint(w, "}\n```\n\n")
+	case t.Underlying != "":
+		op := "="
+		if !t.IsAlias {
+			op = ""
+		}
+		fmt.Fprintf(w, "```go\ntype %s %s%s\n```\n\n", t.Name, op, t.Underlying)
+	}
+
+	if t.Docs != "" {
+		fmt.Fprintf(w, "%s\n\n", t.Docs)
+	}
+}
+
+// renderMarkdownValue writes one const or var's type, value, and doc comment
+// as a Markdown subsection.
+func renderMarkdownValue(w io.Writer, v ValueDetail) {
+	fmt.Fprintf(w, "\n### `%s`\n\n", v.Name)
+
+	if v.Type != "" || v.Value != "" {
+		fmt.Fprintf(w, "```go\n%s %s = %s\n```\n\n", v.Name, v.Type, v.Value)
+	}
+
+	if v.Docs != "" {
+		fmt.Fprintf(w, "%s\n\n", v.Docs)
+	}
+}
+
+// renderMarkdownFunction writes one function or method's signature, params,
+// return types, and doc comment as a Markdown subsection.
+func renderMarkdownFunction(w io.Writer, fn FunctionDetail) {
+	heading := fn.Name
+	if fn.Receiver != "" {
+		heading = fmt.Sprintf("(%s) %s", fn.Receiver, fn.Name)
+	}
+	fmt.Fprintf(w, "\n### `%s`\n\n", heading)
+	fmt.Fprintf(w, "```go\nfunc %s(%s) %s\n```\n\n", fn.Name, strings.Join(fn.ParamTypes, ", "), strings.Join(fn.ReturnTypes, ", "))
+
+	if len(fn.Params) > 0 {
+		fmt.Fprintf(w, "**Params:** %s\n\n", strings.Join(fn.Params, ", "))
+	}
+
+	if fn.Docs != "" {
+		fmt.Fprintf(w, "%s\n\n", fn.Docs)
+	}
+}
+
+// splitFunctionsAndMethods separates plain functions from methods, as
+// identified by a non-empty Receiver.
+func splitFunctionsAndMethods(all []FunctionDetail) (functions, methods []FunctionDetail) {
+	for _, fn := range all {
+		if fn.Receiver != "" {
+			methods = append(methods, fn)
+		} else {
+			functions = append(functions, fn)
+		}
+	}
+	return functions, methods
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownRendererRender(t *testing.T) {
+	tests := []struct {
+		name string
+		data FileData
+		want []string // substrings that must appear, in order
+	}{
+		{
+			name: "package and imports",
+			data: FileData{
+				Package: "widgets",
+				Imports: []string{"fmt", "io"},
+			},
+			want: []string{"# Package `widgets`", "## Imports", "- `fmt`", "- `io`"},
+		},
+		{
+			name: "struct type with fields",
+			data: FileData{
+				Package: "widgets",
+				Types: []TypeDetail{
+					{
+						Name: "Widget",
+						Docs: "Widget is a thing.",
+						Fields: []FieldDetail{
+							{Name: "ID", Type: "string"},
+						},
+					},
+				},
+			},
+			want: []string{"### `Widget`", "| ID | `string` |", "Widget is a thing."},
+		},
+		{
+			name: "interface type with methods",
+			data: FileData{
+				Package: "widgets",
+				Types: []TypeDetail{
+					{
+						Name: "Builder",
+						Methods: []MethodSig{
+							{Name: "Build", ReturnTypes: []string{"Widget", "error"}},
+						},
+					},
+				},
+			},
+			want: []string{"type Builder interface {", "Build() Widget, error"},
+		},
+		{
+			name: "function and method sections are split",
+			data: FileData{
+				Package: "widgets",
+				Functions: []FunctionDetail{
+					{Name: "New", ParamTypes: []string{"string"}, ReturnTypes: []string{"*Widget"}},
+					{Name: "String", Receiver: "*Widget", ReturnTypes: []string{"string"}},
+				},
+			},
+			want: []string{"## Functions", "### `New`", "## Methods", "### `(*Widget) String`"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (MarkdownRenderer{}).Render(tt.data, &buf); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			out := buf.String()
+
+			pos := -1
+			for _, want := range tt.want {
+				idx := strings.Index(out, want)
+				if idx == -1 {
+					t.Fatalf("output missing %q; got:\n%s", want, out)
+				}
+				if idx < pos {
+					t.Errorf("expected %q to appear after previous match; got:\n%s", want, out)
+				}
+				pos = idx
+			}
+		})
+	}
+}
+
+func TestMarkdownRendererExt(t *testing.T) {
+	if got := (MarkdownRenderer{}).Ext(); got != ".md" {
+		t.Errorf("Ext() = %q, want %q", got, ".md")
+	}
+}
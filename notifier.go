@@ -0,0 +1,48 @@
+package main
+
+import "sync"
+
+// reloadNotifier fans out a reload signal to every connected websocket
+// client whenever the FileWatcher reparses or removes a file, so an open
+// docs page can refresh itself.
+type reloadNotifier struct {
+	mu        sync.Mutex
+	listeners map[chan struct{}]struct{}
+}
+
+// newReloadNotifier creates an empty reloadNotifier.
+func newReloadNotifier() *reloadNotifier {
+	return &reloadNotifier{listeners: make(map[chan struct{}]struct{})}
+}
+
+// Subscribe registers a new listener channel that receives a value each
+// time Broadcast is called. Call the returned unsubscribe func when done
+// listening, which also closes the channel.
+func (n *reloadNotifier) Subscribe() (ch chan struct{}, unsubscribe func()) {
+	ch = make(chan struct{}, 1)
+
+	n.mu.Lock()
+	n.listeners[ch] = struct{}{}
+	n.mu.Unlock()
+
+	return ch, func() {
+		n.mu.Lock()
+		delete(n.listeners, ch)
+		n.mu.Unlock()
+		close(ch)
+	}
+}
+
+// Broadcast notifies every subscribed listener without blocking on a slow
+// or unread channel.
+func (n *reloadNotifier) Broadcast() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.listeners {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -1,61 +1,200 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
 	"go/ast"
 	"go/parser"
+	"go/printer"
 	"go/token"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
 // FileParser struct
 type FileParser struct {
-	docMap map[string]FileData // Map to hold file names and their corresponding doc comments and function details
+	mu         sync.Mutex          // Guards docMap against concurrent access from debounced parses and removals
+	docMap     map[string]FileData // Map to hold file names and their corresponding doc comments and function details
+	rootDir    string              // Directory parsed files are resolved relative to, for mirroring into references/
+	renderers  []Renderer          // Output formats written for each parsed file
+	resolverMu sync.RWMutex        // Guards resolver against RefreshResolver racing with a concurrent reparse
+	resolver   *typeResolver       // Optional go/types resolution; nil unless package-mode is enabled
 }
 
 // FileData represents the structure of the data stored for each file
 type FileData struct {
 	Package   string           `json:"package"`
 	Imports   []string         `json:"imports"`
+	Types     []TypeDetail     `json:"types"`
+	Constants []ValueDetail    `json:"constants"`
+	Variables []ValueDetail    `json:"variables"`
 	Functions []FunctionDetail `json:"functions"`
 }
 
-// FunctionDetail represents the structure of function details
+// FunctionDetail represents the structure of function and method details
 type FunctionDetail struct {
 	Name        string   `json:"name"`
-	Docs        string   `json:"docs"` // Associate documentation with functions
+	Docs        string   `json:"docs"`               // Associate documentation with functions
+	Receiver    string   `json:"receiver,omitempty"` // Set for methods, e.g. "*Foo"
+	Exported    bool     `json:"exported"`
 	Params      []string `json:"params"`
 	ParamTypes  []string `json:"param_types"`
 	ReturnTypes []string `json:"return_types"`
 }
 
-// NewFileParser initializes a new FileParser
-func NewFileParser() *FileParser {
-	return &FileParser{docMap: make(map[string]FileData)}
+// TypeDetail represents a top-level type declaration: a struct, an
+// interface, or a defined/alias type.
+type TypeDetail struct {
+	Name       string        `json:"name"`
+	Docs       string        `json:"docs"`
+	Exported   bool          `json:"exported"`
+	IsAlias    bool          `json:"is_alias"`
+	Underlying string        `json:"underlying,omitempty"` // For non-struct, non-interface types
+	Fields     []FieldDetail `json:"fields,omitempty"`     // Struct fields
+	Methods    []MethodSig   `json:"methods,omitempty"`    // Interface method set
+}
+
+// FieldDetail represents a single struct field.
+type FieldDetail struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Tag  string `json:"tag,omitempty"`
+	Docs string `json:"docs,omitempty"`
+}
+
+// MethodSig represents a method declared in an interface's method set.
+type MethodSig struct {
+	Name        string   `json:"name"`
+	Docs        string   `json:"docs,omitempty"`
+	ParamTypes  []string `json:"param_types"`
+	ReturnTypes []string `json:"return_types"`
+}
+
+// ValueDetail represents a single top-level const or var declaration.
+type ValueDetail struct {
+	Name     string `json:"name"`
+	Docs     string `json:"docs,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Exported bool   `json:"exported"`
+}
+
+// NewFileParser initializes a new FileParser that resolves parsed files
+// relative to rootDir and writes each one with the given renderers. With no
+// renderers given, it defaults to JSON output.
+func NewFileParser(rootDir string, renderers ...Renderer) *FileParser {
+	if len(renderers) == 0 {
+		renderers = []Renderer{JSONRenderer{}}
+	}
+
+	return &FileParser{
+		docMap:    make(map[string]FileData),
+		rootDir:   rootDir,
+		renderers: renderers,
+	}
+}
+
+// Renderers returns the output formats this parser writes for each parsed file.
+func (fp *FileParser) Renderers() []Renderer {
+	return fp.renderers
+}
+
+// SetTypeResolver enables package-mode: types are recorded using their
+// go/types-resolved, fully-qualified spelling wherever resolution succeeds,
+// instead of the bare identifier the AST gives us.
+func (fp *FileParser) SetTypeResolver(resolver *typeResolver) {
+	fp.resolverMu.Lock()
+	defer fp.resolverMu.Unlock()
+	fp.resolver = resolver
+}
+
+// Resolver returns the type resolver currently in effect, or nil if
+// package-mode isn't enabled.
+func (fp *FileParser) Resolver() *typeResolver {
+	fp.resolverMu.RLock()
+	defer fp.resolverMu.RUnlock()
+	return fp.resolver
+}
+
+// RefreshResolver reloads go/types information for rootDir and swaps it in,
+// so that a reparse triggered after this call sees the edit that triggered
+// it. Without this, the resolver built once at startup keeps resolving
+// types against stale ast.Expr nodes after the first edit to any file, and
+// every subsequent reparse silently falls back to the unqualified go/printer
+// spelling. It is a no-op when package-mode isn't enabled.
+func (fp *FileParser) RefreshResolver() {
+	if fp.Resolver() == nil {
+		return
+	}
+
+	resolver, err := loadTypeResolver(fp.rootDir)
+	if err != nil {
+		log.Printf("Error refreshing type resolver: %v", err)
+		return
+	}
+	fp.SetTypeResolver(resolver)
 }
 
 // ParseFile parses the Go file and extracts documentation comments and function details
 func (fp *FileParser) ParseFile(filePath string) {
-	delete(fp.docMap, filePath) // Reset for fresh parse
+	fp.deleteDoc(filePath) // Reset for fresh parse
 	err := fp.tryParseFile(filePath, 3)
 	if err != nil {
 		log.Printf("Error parsing file: %v", err)
 	}
 }
 
+// RemoveFile discards any parsed data held for filePath, so a subsequent
+// rename or delete of the source file no longer shows up in the docMap.
+func (fp *FileParser) RemoveFile(filePath string) {
+	fp.deleteDoc(filePath)
+}
+
+// setDoc, deleteDoc, and getDoc are the only points that touch docMap, so the
+// mutex correctly guards concurrent debounced parses (each on its own
+// time.AfterFunc goroutine) and removals (on the watcher goroutine) racing
+// on the same map.
+func (fp *FileParser) setDoc(filePath string, data FileData) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	fp.docMap[filePath] = data
+}
+
+func (fp *FileParser) deleteDoc(filePath string) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	delete(fp.docMap, filePath)
+}
+
+func (fp *FileParser) getDoc(filePath string) FileData {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	return fp.docMap[filePath]
+}
+
 // tryParseFile attempts to parse the file up to retryCount times to ensure stability
 func (fp *FileParser) tryParseFile(filePath string, retryCount int) error {
+	// In package-mode, use the exact syntax tree go/packages type-checked
+	// for filePath instead of re-parsing it: types.Info is keyed by the
+	// ast.Expr pointers from that original parse, so a fresh parser.ParseFile
+	// call would produce nodes QualifiedType could never resolve.
+	if resolver := fp.Resolver(); resolver != nil {
+		if node, fset, ok := resolver.SyntaxFor(filePath); ok {
+			fp.extractFileData(filePath, node, fset)
+			return nil
+		}
+	}
+
 	var lastErr error
 	for i := 0; i < retryCount; i++ {
 		fset := token.NewFileSet()
 		if fp.fileExistsAndReadable(filePath) {
 			node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
 			if err == nil {
-				fp.extractFileData(filePath, node)
+				fp.extractFileData(filePath, node, fset)
 				return nil
 			}
 			lastErr = err
@@ -71,8 +210,10 @@ func (fp *FileParser) fileExistsAndReadable(filePath string) bool {
 	return err == nil && !info.IsDir() && info.Size() > 0
 }
 
-// extractFileData processes the parsed node and stores it in docMap
-func (fp *FileParser) extractFileData(filePath string, node *ast.File) {
+// extractFileData processes the parsed node and stores it in docMap. fset is
+// the FileSet node was parsed with; it is reused to print types so that
+// positions stay consistent between parsing and formatting.
+func (fp *FileParser) extractFileData(filePath string, node *ast.File, fset *token.FileSet) {
 	fileData := FileData{
 		Package: node.Name.Name,
 	}
@@ -83,57 +224,264 @@ func (fp *FileParser) extractFileData(filePath string, node *ast.File) {
 		}
 	}
 
+	tf := &typeFormatter{fset: fset, resolver: fp.Resolver(), filePath: filePath}
+
 	for _, decl := range node.Decls {
 		switch d := decl.(type) {
 		case *ast.FuncDecl:
-			funcDetail := FunctionDetail{Name: d.Name.Name}
-			if d.Doc != nil {
-				funcDetail.Docs = sanitizeDoc(d.Doc.Text())
-			}
-
-			if d.Type != nil && d.Type.Params != nil {
-				for _, param := range d.Type.Params.List {
-					for _, name := range param.Names {
-						funcDetail.Params = append(funcDetail.Params, name.Name)
+			fileData.Functions = append(fileData.Functions, extractFunctionDetail(d, tf))
+		case *ast.GenDecl:
+			switch d.Tok {
+			case token.TYPE:
+				for _, spec := range d.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
 					}
-					funcDetail.ParamTypes = append(funcDetail.ParamTypes, formatType(param.Type))
+					fileData.Types = append(fileData.Types, extractTypeDetail(d, typeSpec, tf))
 				}
+			case token.CONST:
+				fileData.Constants = append(fileData.Constants, extractValueDetails(d, tf)...)
+			case token.VAR:
+				fileData.Variables = append(fileData.Variables, extractValueDetails(d, tf)...)
 			}
+		}
+	}
+
+	fp.setDoc(filePath, fileData)
+
+	if err := fp.writeOutputs(filePath); err != nil {
+		log.Printf("Error writing output for file: %v", err)
+	}
+}
+
+// extractFunctionDetail builds a FunctionDetail for a top-level function or,
+// when d.Recv is set, a method, recording its receiver type.
+func extractFunctionDetail(d *ast.FuncDecl, tf *typeFormatter) FunctionDetail {
+	funcDetail := FunctionDetail{
+		Name:     d.Name.Name,
+		Exported: ast.IsExported(d.Name.Name),
+	}
+	if d.Doc != nil {
+		funcDetail.Docs = sanitizeDoc(d.Doc.Text())
+	}
+
+	if d.Recv != nil && len(d.Recv.List) > 0 {
+		funcDetail.Receiver = tf.format(d.Recv.List[0].Type)
+	}
 
-			if d.Type != nil && d.Type.Results != nil {
-				for _, result := range d.Type.Results.List {
-					funcDetail.ReturnTypes = append(funcDetail.ReturnTypes, formatType(result.Type))
+	if d.Type != nil && d.Type.Params != nil {
+		for _, param := range d.Type.Params.List {
+			for _, name := range param.Names {
+				funcDetail.Params = append(funcDetail.Params, name.Name)
+			}
+			funcDetail.ParamTypes = append(funcDetail.ParamTypes, tf.format(param.Type))
+		}
+	}
+
+	if d.Type != nil && d.Type.Results != nil {
+		for _, result := range d.Type.Results.List {
+			funcDetail.ReturnTypes = append(funcDetail.ReturnTypes, tf.format(result.Type))
+		}
+	}
+
+	return funcDetail
+}
+
+// extractTypeDetail builds a TypeDetail for a single type declaration,
+// recording struct fields or an interface's method set where applicable.
+func extractTypeDetail(decl *ast.GenDecl, spec *ast.TypeSpec, tf *typeFormatter) TypeDetail {
+	typeDetail := TypeDetail{
+		Name:     spec.Name.Name,
+		Exported: ast.IsExported(spec.Name.Name),
+		IsAlias:  spec.Assign.IsValid(),
+	}
+	typeDetail.Docs = sanitizeDoc(specDoc(decl, spec.Doc))
+
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		typeDetail.Fields = extractFieldDetails(t, tf)
+	case *ast.InterfaceType:
+		typeDetail.Methods = extractMethodSigs(t, tf)
+	default:
+		typeDetail.Underlying = tf.format(spec.Type)
+	}
+
+	return typeDetail
+}
+
+// extractFieldDetails builds a FieldDetail for each field of a struct,
+// including embedded fields, which are recorded under their type name.
+func extractFieldDetails(structType *ast.StructType, tf *typeFormatter) []FieldDetail {
+	if structType.Fields == nil {
+		return nil
+	}
+
+	var fields []FieldDetail
+	for _, field := range structType.Fields.List {
+		typeName := tf.format(field.Type)
+		tag := ""
+		if field.Tag != nil {
+			tag = strings.Trim(field.Tag.Value, "`")
+		}
+		docs := ""
+		if field.Doc != nil {
+			docs = sanitizeDoc(field.Doc.Text())
+		}
+
+		if len(field.Names) == 0 {
+			// Embedded field; its type name doubles as the field name.
+			fields = append(fields, FieldDetail{Name: typeName, Type: typeName, Tag: tag, Docs: docs})
+			continue
+		}
+
+		for _, name := range field.Names {
+			fields = append(fields, FieldDetail{Name: name.Name, Type: typeName, Tag: tag, Docs: docs})
+		}
+	}
+	return fields
+}
+
+// extractMethodSigs builds a MethodSig for each named method in an
+// interface's method set, skipping embedded interfaces.
+func extractMethodSigs(interfaceType *ast.InterfaceType, tf *typeFormatter) []MethodSig {
+	if interfaceType.Methods == nil {
+		return nil
+	}
+
+	var methods []MethodSig
+	for _, method := range interfaceType.Methods.List {
+		if len(method.Names) == 0 {
+			continue // Embedded interface
+		}
+
+		funcType, ok := method.Type.(*ast.FuncType)
+		if !ok {
+			continue
+		}
+
+		for _, name := range method.Names {
+			sig := MethodSig{Name: name.Name}
+			if method.Doc != nil {
+				sig.Docs = sanitizeDoc(method.Doc.Text())
+			}
+			if funcType.Params != nil {
+				for _, param := range funcType.Params.List {
+					sig.ParamTypes = append(sig.ParamTypes, tf.format(param.Type))
+				}
+			}
+			if funcType.Results != nil {
+				for _, result := range funcType.Results.List {
+					sig.ReturnTypes = append(sig.ReturnTypes, tf.format(result.Type))
 				}
 			}
-			fileData.Functions = append(fileData.Functions, funcDetail)
+			methods = append(methods, sig)
+		}
+	}
+	return methods
+}
+
+// extractValueDetails builds a ValueDetail for each name declared by a const
+// or var GenDecl, across all of its specs.
+func extractValueDetails(decl *ast.GenDecl, tf *typeFormatter) []ValueDetail {
+	var values []ValueDetail
+
+	for _, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		typeName := tf.format(valueSpec.Type)
+		docs := sanitizeDoc(specDoc(decl, valueSpec.Doc))
+
+		for i, name := range valueSpec.Names {
+			detail := ValueDetail{
+				Name:     name.Name,
+				Docs:     docs,
+				Type:     typeName,
+				Exported: ast.IsExported(name.Name),
+			}
+			if i < len(valueSpec.Values) {
+				detail.Value = formatValueExpr(valueSpec.Values[i], tf)
+			}
+			values = append(values, detail)
 		}
 	}
 
-	fp.docMap[filePath] = fileData
+	return values
+}
+
+// specDoc returns the doc comment for an individual spec within a
+// possibly-grouped declaration, falling back to the declaration's own doc
+// comment for ungrouped, single-spec declarations.
+func specDoc(decl *ast.GenDecl, doc *ast.CommentGroup) string {
+	if doc != nil {
+		return doc.Text()
+	}
+	if !decl.Lparen.IsValid() && decl.Doc != nil {
+		return decl.Doc.Text()
+	}
+	return ""
+}
 
-	if err := fp.writeJSONToFile(filePath); err != nil {
-		log.Printf("Error writing JSON to file: %v", err)
+// formatValueExpr renders the simple literal and identifier expressions
+// commonly used as const/var initializers.
+func formatValueExpr(expr ast.Expr, tf *typeFormatter) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return e.Value
+	case *ast.Ident:
+		return e.Name
+	case *ast.UnaryExpr:
+		return e.Op.String() + formatValueExpr(e.X, tf)
+	case *ast.SelectorExpr:
+		return tf.format(e)
+	default:
+		return ""
 	}
 }
 
-// writeJSONToFile writes the documentation map to a JSON file in the references directory
-func (fp *FileParser) writeJSONToFile(jsonFilePath string) error {
-	// Create the references directory if it doesn't exist
-	dir := filepath.Dir(jsonFilePath)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+// writeOutputs renders filePath's parsed data into references/ once per
+// configured renderer, then refreshes the HTML index if HTML was among them.
+func (fp *FileParser) writeOutputs(filePath string) error {
+	basePath, err := referenceBasePath(fp.rootDir, filePath)
+	if err != nil {
 		return err
 	}
 
-	// Create the JSON file
-	file, err := os.Create(jsonFilePath)
+	if err := os.MkdirAll(filepath.Dir(basePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	wroteHTML := false
+	for _, renderer := range fp.renderers {
+		if err := fp.writeRendered(renderer, basePath+renderer.Ext(), filePath); err != nil {
+			return err
+		}
+		if _, ok := renderer.(HTMLRenderer); ok {
+			wroteHTML = true
+		}
+	}
+
+	if wroteHTML {
+		if err := writeHTMLIndex(); err != nil {
+			log.Printf("Error writing HTML index: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// writeRendered writes filePath's parsed data to outPath using renderer.
+func (fp *FileParser) writeRendered(renderer Renderer, outPath, filePath string) error {
+	file, err := os.Create(outPath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(fp.docMap[jsonFilePath])
+	return renderer.Render(fp.getDoc(filePath), file)
 }
 
 // sanitizeDoc removes newlines and trims spaces from documentation strings
@@ -141,55 +489,34 @@ func sanitizeDoc(doc string) string {
 	return strings.TrimSpace(strings.ReplaceAll(doc, "\n", " "))
 }
 
-// formatType converts an ast.Expr to a string representation
-func formatType(expr ast.Expr) string {
+// typeFormatter renders ast.Expr type nodes to the string stored in
+// FileData. It always falls back to go/printer, which (unlike a hand-rolled
+// switch) handles every expression shape the AST can produce, including
+// generics (IndexExpr, IndexListExpr), ParenExpr, Ellipsis, and directional
+// channels. When resolver is set, it is tried first so that identifiers are
+// recorded fully qualified by their declaring package.
+type typeFormatter struct {
+	fset     *token.FileSet // Shared with the parse that produced expr, so printer positions stay valid
+	resolver *typeResolver  // Optional go/types resolution; nil unless package-mode is enabled
+	filePath string         // File expr was parsed from, for resolver lookups
+}
+
+// format renders expr, preferring the resolver's fully-qualified go/types
+// spelling and falling back to the AST's own syntax via go/printer.
+func (tf *typeFormatter) format(expr ast.Expr) string {
 	if expr == nil {
 		return ""
 	}
 
-	switch t := expr.(type) {
-	case *ast.Ident:
-		return t.Name
-	case *ast.SelectorExpr:
-		return t.X.(*ast.Ident).Name + "." + t.Sel.Name
-	case *ast.ArrayType:
-		return "[]" + formatType(t.Elt)
-	case *ast.MapType:
-		return "map[" + formatType(t.Key) + "]" + formatType(t.Value)
-	case *ast.StarExpr:
-		return "*" + formatType(t.X)
-	case *ast.FuncType:
-		return "func" + formatFuncType(t)
-	case *ast.ChanType:
-		return "chan " + formatType(t.Value)
-	case *ast.InterfaceType:
-		return "interface{}"
-	default:
-		if strType, ok := expr.(*ast.Ident); ok && strType.Name == "interface" {
-			return "interface{}"
-		}
-		if strType, ok := expr.(*ast.MapType); ok {
-			return "map[" + formatType(strType.Key) + "]" + formatType(strType.Value)
+	if tf.resolver != nil {
+		if qualified, ok := tf.resolver.QualifiedType(tf.filePath, expr); ok {
+			return qualified
 		}
-		if strType, ok := expr.(*ast.ArrayType); ok {
-			return "[]" + formatType(strType.Elt)
-		}
-		return "<unknown type>"
 	}
-}
 
-// formatFuncType formats the function type
-func formatFuncType(funcType *ast.FuncType) string {
-	paramTypes := ""
-	if funcType.Params != nil {
-		paramTypes += "("
-		for i, param := range funcType.Params.List {
-			paramTypes += formatType(param.Type)
-			if i < len(funcType.Params.List)-1 {
-				paramTypes += ", "
-			}
-		}
-		paramTypes += ")"
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, tf.fset, expr); err != nil {
+		return "<unknown type>"
 	}
-	return paramTypes
+	return buf.String()
 }
@@ -0,0 +1,11 @@
+package main
+
+import "io"
+
+// Renderer produces one output format's representation of a parsed file's data.
+type Renderer interface {
+	// Render writes data's documentation to w.
+	Render(data FileData, w io.Writer) error
+	// Ext returns the file extension (including the leading dot) this renderer produces.
+	Ext() string
+}
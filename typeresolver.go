@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"log"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// resolvedFile holds everything QualifiedType needs for one file that
+// go/packages type-checked: the exact syntax tree and FileSet go/types
+// resolved positions against (types.Info maps are keyed by ast.Expr
+// pointers, so a separate re-parse of the same file never matches), the
+// type information itself, and the path of the package being documented
+// (so same-package identifiers can be left unqualified).
+type resolvedFile struct {
+	fset    *token.FileSet
+	file    *ast.File
+	info    *types.Info
+	pkgPath string
+}
+
+// typeResolver maps a parsed AST expression back to its go/types type,
+// letting FileParser record fully-qualified types (e.g. "io.Writer", not
+// just "Writer") instead of the bare spelling the AST gives us. It is built
+// once per watched directory by loadTypeResolver and is nil unless
+// package-mode is enabled.
+type typeResolver struct {
+	files map[string]*resolvedFile // keyed by absolute file path
+}
+
+// loadTypeResolver loads every package under dir with full type information
+// via golang.org/x/tools/go/packages, enabling package-mode. The returned
+// resolver covers every file packages.Load type-checked for dir; files
+// outside that set (or with load errors) simply fail to resolve, and
+// callers fall back to unqualified AST formatting for them.
+func loadTypeResolver(dir string) (*typeResolver, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo,
+		Dir: dir,
+	}
+
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("loading packages under %s: %w", dir, err)
+	}
+
+	resolver := &typeResolver{files: make(map[string]*resolvedFile)}
+	for _, pkg := range pkgs {
+		for _, loadErr := range pkg.Errors {
+			log.Printf("package load error in %s: %v", pkg.PkgPath, loadErr)
+		}
+		for _, file := range pkg.Syntax {
+			// Ask the FileSet for the name it parsed this syntax tree from,
+			// rather than assuming pkg.Syntax lines up index-for-index with
+			// pkg.GoFiles: that keeps FileParser's lookups (and the AST
+			// nodes it type-checks) keyed to exactly the file go/types saw.
+			abs, err := filepath.Abs(pkg.Fset.Position(file.Pos()).Filename)
+			if err != nil {
+				continue
+			}
+			resolver.files[abs] = &resolvedFile{
+				fset:    pkg.Fset,
+				file:    file,
+				info:    pkg.TypesInfo,
+				pkgPath: pkg.PkgPath,
+			}
+		}
+	}
+
+	return resolver, nil
+}
+
+// SyntaxFor returns the syntax tree and FileSet go/packages type-checked for
+// filePath, if any. FileParser uses this instead of re-parsing filePath
+// itself whenever a resolver is set, so the ast.Expr nodes it later hands to
+// QualifiedType are the very ones types.Info was built from.
+func (tr *typeResolver) SyntaxFor(filePath string) (*ast.File, *token.FileSet, bool) {
+	rf, ok := tr.lookup(filePath)
+	if !ok {
+		return nil, nil, false
+	}
+	return rf.file, rf.fset, true
+}
+
+// QualifiedType returns the go/types-resolved string for expr as it appears
+// in filePath, and whether resolution succeeded. Types in the package being
+// documented are left bare (e.g. "FieldDetail", not "pkg/path.FieldDetail"),
+// matching the unqualified spelling the go/printer fallback produces for
+// same-package identifiers.
+func (tr *typeResolver) QualifiedType(filePath string, expr ast.Expr) (string, bool) {
+	rf, ok := tr.lookup(filePath)
+	if !ok {
+		return "", false
+	}
+
+	typ := rf.info.TypeOf(expr)
+	if typ == nil {
+		return "", false
+	}
+
+	qualifier := func(pkg *types.Package) string {
+		if pkg.Path() == rf.pkgPath {
+			return ""
+		}
+		return pkg.Name()
+	}
+
+	return types.TypeString(typ, qualifier), true
+}
+
+// lookup resolves filePath to the resolvedFile go/packages produced for it,
+// normalizing to an absolute path since FileParser may pass either an
+// absolute path (package-mode walks) or one relative to the current
+// directory (the file watcher's event paths).
+func (tr *typeResolver) lookup(filePath string) (*resolvedFile, bool) {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, false
+	}
+	rf, ok := tr.files[abs]
+	return rf, ok
+}